@@ -13,9 +13,12 @@ import (
 type Option func(*configOptions)
 
 type configOptions struct {
-	prefix    string
-	skipEnv   bool
-	skipValid bool
+	prefix         string
+	skipEnv        bool
+	skipValid      bool
+	secretResolver SecretResolver
+	configFiles    []string
+	reloadSignal   os.Signal
 }
 
 func WithPrefix(prefix string) Option {
@@ -36,6 +39,24 @@ func SkipValidation() Option {
 	}
 }
 
+// WithConfigFile loads a TOML, YAML, or JSON file (dispatched by extension)
+// into dest before env vars are applied. See WithConfigFiles to layer more
+// than one.
+func WithConfigFile(path string) Option {
+	return func(o *configOptions) {
+		o.configFiles = append(o.configFiles, path)
+	}
+}
+
+// WithConfigFiles loads multiple config files in order, each only
+// overriding the keys it sets, so e.g. a committed config.toml can be
+// layered with a per-environment config.prod.toml.
+func WithConfigFiles(paths ...string) Option {
+	return func(o *configOptions) {
+		o.configFiles = append(o.configFiles, paths...)
+	}
+}
+
 func Load(dest interface{}, opts ...Option) error {
 	options := &configOptions{}
 	for _, opt := range opts {
@@ -53,6 +74,12 @@ func Load(dest interface{}, opts ...Option) error {
 		}
 	}
 
+	if len(options.configFiles) > 0 {
+		if err := loadConfigFiles(dest, options.configFiles); err != nil {
+			return err
+		}
+	}
+
 	err := env.ParseWithOptions(dest, env.Options{
 		Prefix: options.prefix,
 	})
@@ -60,6 +87,12 @@ func Load(dest interface{}, opts ...Option) error {
 		return err
 	}
 
+	if options.secretResolver != nil {
+		if err := resolveSecrets(dest, options.secretResolver); err != nil {
+			return err
+		}
+	}
+
 	defaults.SetDefaults(dest)
 
 	if !options.skipValid {
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfigFiles decodes paths in order, deep-merging them into a single
+// map (a later file only overrides the keys it sets), then applies the
+// result onto dest.
+func loadConfigFiles(dest interface{}, paths []string) error {
+	merged := map[string]interface{}{}
+
+	for _, path := range paths {
+		data, err := decodeConfigFile(path)
+		if err != nil {
+			return fmt.Errorf("config: file %q: %w", path, err)
+		}
+		mergeConfigMaps(merged, data)
+	}
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("config: files: expected a pointer, got %T", dest)
+	}
+
+	return applyConfigMap(v.Elem(), merged)
+}
+
+func decodeConfigFile(path string) (map[string]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data := map[string]interface{}{}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		if _, err := toml.NewDecoder(f).Decode(&data); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		if err := yaml.NewDecoder(f).Decode(&data); err != nil {
+			return nil, err
+		}
+	case ".json":
+		if err := json.NewDecoder(f).Decode(&data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+
+	return normalizeConfigMap(data), nil
+}
+
+// normalizeConfigMap recursively converts map[interface{}]interface{} and
+// map[string]interface{} sub-maps (as produced by different decoders) to a
+// single map[string]interface{} shape so merging and lookups are uniform.
+func normalizeConfigMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = normalizeConfigValue(v)
+	}
+	return out
+}
+
+func normalizeConfigValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return normalizeConfigMap(vv)
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(vv))
+		for k, val := range vv {
+			m[fmt.Sprint(k)] = normalizeConfigValue(val)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
+// mergeConfigMaps merges src into dst in place; nested maps are merged
+// recursively, everything else (including slices) is replaced wholesale.
+func mergeConfigMaps(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if sv, ok := v.(map[string]interface{}); ok {
+			if dv, ok := dst[k].(map[string]interface{}); ok {
+				mergeConfigMaps(dv, sv)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// applyConfigMap populates v's fields from data. A `config:"foo.bar"` tag
+// looks up a dotted path from the root of data; otherwise the lowercased
+// `env:"FOO"` tag is used as a top-level key, and untagged struct fields
+// recurse into the section matching their lowercased field name.
+func applyConfigMap(v reflect.Value, data map[string]interface{}) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		if cfgTag, ok := field.Tag.Lookup("config"); ok {
+			val, ok := lookupConfigPath(data, cfgTag)
+			if !ok {
+				continue
+			}
+			if err := setFromAny(fv, val); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			continue
+		}
+
+		// Structs that decode from a single scalar (e.g. url.URL via
+		// encoding.TextUnmarshaler) are env-tagged like any other scalar and
+		// must not be treated as a nested section.
+		if fv.Kind() == reflect.Struct && !isScalarStruct(fv) {
+			section, _ := data[strings.ToLower(field.Name)].(map[string]interface{})
+			if err := applyConfigMap(fv, section); err != nil {
+				return err
+			}
+			continue
+		}
+
+		envTag, ok := field.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		val, ok := data[strings.ToLower(envTag)]
+		if !ok {
+			continue
+		}
+		if err := setFromAny(fv, val); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func lookupConfigPath(data map[string]interface{}, path string) (interface{}, bool) {
+	segments := strings.Split(path, ".")
+
+	var cur interface{} = data
+	for _, seg := range segments {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[seg]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+// isScalarStruct reports whether fv's address implements
+// encoding.TextUnmarshaler or encoding.BinaryUnmarshaler, the mechanisms
+// caarlos0/env relies on (directly, or via its built-in url.URL parser) to
+// decode a struct field from a single string. Such fields are env-tagged
+// like any other scalar, not recursed into as a nested section.
+func isScalarStruct(fv reflect.Value) bool {
+	if !fv.CanAddr() {
+		return false
+	}
+
+	addr := fv.Addr().Interface()
+	if _, ok := addr.(encoding.TextUnmarshaler); ok {
+		return true
+	}
+	_, ok := addr.(encoding.BinaryUnmarshaler)
+	return ok
+}
+
+// setFromAny assigns val (as decoded from TOML/YAML/JSON) onto fv. Types
+// implementing encoding.TextUnmarshaler or encoding.BinaryUnmarshaler (e.g.
+// url.URL) take priority, matching how caarlos0/env decodes the same
+// fields; scalars go through setFromString for the same conversion rules
+// env uses; everything else is round-tripped through JSON to support
+// nested structs, slices, and maps generically.
+func setFromAny(fv reflect.Value, val interface{}) error {
+	if fv.CanAddr() {
+		addr := fv.Addr().Interface()
+
+		s, isString := val.(string)
+		if !isString {
+			s = fmt.Sprintf("%v", val)
+		}
+
+		if tu, ok := addr.(encoding.TextUnmarshaler); ok {
+			return tu.UnmarshalText([]byte(s))
+		}
+		if bu, ok := addr.(encoding.BinaryUnmarshaler); ok {
+			return bu.UnmarshalBinary([]byte(s))
+		}
+	}
+
+	switch fv.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return setFromString(fv, fmt.Sprintf("%v", val))
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, fv.Addr().Interface())
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fileConfig struct {
+	Host   string `env:"FILE_HOST"`
+	Port   int    `env:"FILE_PORT"`
+	Nested struct {
+		Name string `env:"NAME"`
+	}
+	Deep string `config:"nested.deep"`
+}
+
+type fileConfigWithURL struct {
+	Endpoint url.URL `env:"ENDPOINT"`
+}
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadWithConfigFile(t *testing.T) {
+	t.Run("toml", func(t *testing.T) {
+		path := writeTempFile(t, "config.toml", "file_host = \"tomlhost\"\nfile_port = 1111\n")
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.NoError(t, err)
+
+		assert.Equal(t, "tomlhost", cfg.Host)
+		assert.Equal(t, 1111, cfg.Port)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		path := writeTempFile(t, "config.yaml", "file_host: yamlhost\nfile_port: 2222\n")
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.NoError(t, err)
+
+		assert.Equal(t, "yamlhost", cfg.Host)
+		assert.Equal(t, 2222, cfg.Port)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := writeTempFile(t, "config.json", `{"file_host": "jsonhost", "file_port": 3333}`)
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.NoError(t, err)
+
+		assert.Equal(t, "jsonhost", cfg.Host)
+		assert.Equal(t, 3333, cfg.Port)
+	})
+
+	t.Run("config tag reads a nested dotted path", func(t *testing.T) {
+		path := writeTempFile(t, "config.toml", "[nested]\ndeep = \"buried\"\n")
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.NoError(t, err)
+
+		assert.Equal(t, "buried", cfg.Deep)
+	})
+
+	t.Run("env vars override file values", func(t *testing.T) {
+		path := writeTempFile(t, "config.toml", "file_host = \"tomlhost\"\nfile_port = 1111\n")
+		os.Setenv("FILE_PORT", "9999")
+		defer os.Unsetenv("FILE_PORT")
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.NoError(t, err)
+
+		assert.Equal(t, "tomlhost", cfg.Host)
+		assert.Equal(t, 9999, cfg.Port)
+	})
+
+	t.Run("later file only overrides the keys it sets", func(t *testing.T) {
+		base := writeTempFile(t, "base.toml", "file_host = \"basehost\"\nfile_port = 1111\n")
+		override := writeTempFile(t, "override.toml", "file_port = 4444\n")
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFiles(base, override))
+		require.NoError(t, err)
+
+		assert.Equal(t, "basehost", cfg.Host)
+		assert.Equal(t, 4444, cfg.Port)
+	})
+
+	t.Run("env-tagged TextUnmarshaler field is treated as a scalar, not a section", func(t *testing.T) {
+		path := writeTempFile(t, "config.toml", "endpoint = \"https://example.com/path\"\n")
+
+		cfg := &fileConfigWithURL{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://example.com/path", cfg.Endpoint.String())
+	})
+
+	t.Run("unsupported extension errors", func(t *testing.T) {
+		path := writeTempFile(t, "config.ini", "host = badext")
+
+		cfg := &fileConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported")
+	})
+}
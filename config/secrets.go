@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SecretResolver reads the key/value pairs stored at path in a secrets
+// backend. Implementations are expected to return plain string values,
+// matching the shape of Vault's KV v2 `data.data` payload. The config/vault
+// sub-package provides an implementation backed by a real Vault client, kept
+// out of this package so callers who don't need Vault aren't forced to pull
+// in its dependencies.
+type SecretResolver interface {
+	Resolve(path string) (map[string]string, error)
+}
+
+// WithSecretResolver enables the `secret:"path#key"` struct tag, resolving
+// tagged fields against r during Load.
+func WithSecretResolver(r SecretResolver) Option {
+	return func(o *configOptions) {
+		o.secretResolver = r
+	}
+}
+
+// resolveSecrets walks dest for fields tagged `secret:"path#key"` and
+// populates them from r, caching reads by path so a struct referencing the
+// same secret multiple times only fetches it once.
+func resolveSecrets(dest interface{}, r SecretResolver) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("config: secrets: expected a pointer, got %T", dest)
+	}
+
+	cache := make(map[string]map[string]string)
+	return resolveSecretsValue(v.Elem(), r, cache)
+}
+
+func resolveSecretsValue(v reflect.Value, r SecretResolver, cache map[string]map[string]string) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				continue
+			}
+			if err := resolveSecretsValue(fv.Elem(), r, cache); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fv.Kind() == reflect.Struct {
+			if err := resolveSecretsValue(fv, r, cache); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("secret")
+		if !ok {
+			continue
+		}
+
+		path, key, ok := strings.Cut(tag, "#")
+		if !ok {
+			return fmt.Errorf("config: secrets: field %q: invalid secret tag %q, want \"path#key\"", field.Name, tag)
+		}
+
+		data, ok := cache[path]
+		if !ok {
+			var err error
+			data, err = r.Resolve(path)
+			if err != nil {
+				return fmt.Errorf("config: secrets: resolve %q: %w", path, err)
+			}
+			cache[path] = data
+		}
+
+		value, ok := data[key]
+		if !ok {
+			return fmt.Errorf("config: secrets: %q has no key %q", path, key)
+		}
+
+		if err := setFromString(fv, value); err != nil {
+			return fmt.Errorf("config: secrets: field %q from %q: %w", field.Name, path, err)
+		}
+	}
+
+	return nil
+}
+
+// setFromString assigns value to fv, converting it to fv's concrete type
+// with the same rules env uses for scalar fields.
+func setFromString(fv reflect.Value, value string) error {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported kind %s", fv.Kind())
+	}
+
+	return nil
+}
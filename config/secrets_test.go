@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	reads int
+	data  map[string]map[string]string
+	err   error
+}
+
+func (f *fakeResolver) Resolve(path string) (map[string]string, error) {
+	f.reads++
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, ok := f.data[path]
+	if !ok {
+		return nil, fmt.Errorf("no secret at %q", path)
+	}
+	return data, nil
+}
+
+type secretConfig struct {
+	Host     string `env:"HOST"`
+	Password string `secret:"secret/data/myapp/db#password"`
+	Port     int    `secret:"secret/data/myapp/db#port"`
+}
+
+func TestLoadWithSecretResolver(t *testing.T) {
+	t.Run("resolves tagged fields", func(t *testing.T) {
+		resolver := &fakeResolver{
+			data: map[string]map[string]string{
+				"secret/data/myapp/db": {"password": "hunter2", "port": "5432"},
+			},
+		}
+
+		cfg := &secretConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithSecretResolver(resolver))
+		require.NoError(t, err)
+
+		assert.Equal(t, "hunter2", cfg.Password)
+		assert.Equal(t, 5432, cfg.Port)
+	})
+
+	t.Run("caches reads by path", func(t *testing.T) {
+		resolver := &fakeResolver{
+			data: map[string]map[string]string{
+				"secret/data/myapp/db": {"password": "hunter2", "port": "5432"},
+			},
+		}
+
+		cfg := &secretConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithSecretResolver(resolver))
+		require.NoError(t, err)
+		assert.Equal(t, 1, resolver.reads)
+	})
+
+	t.Run("missing key error includes path but not value", func(t *testing.T) {
+		resolver := &fakeResolver{
+			data: map[string]map[string]string{
+				"secret/data/myapp/db": {"password": "hunter2"},
+			},
+		}
+
+		cfg := &secretConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithSecretResolver(resolver))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "secret/data/myapp/db")
+		assert.NotContains(t, err.Error(), "hunter2")
+	})
+
+	t.Run("resolver error is wrapped with path", func(t *testing.T) {
+		resolver := &fakeResolver{err: fmt.Errorf("connection refused")}
+
+		cfg := &secretConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation(), WithSecretResolver(resolver))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "secret/data/myapp/db")
+	})
+
+	t.Run("no resolver configured leaves tagged fields untouched", func(t *testing.T) {
+		cfg := &secretConfig{}
+		err := Load(cfg, SkipEnvFile(), SkipValidation())
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Password)
+	})
+}
@@ -0,0 +1,65 @@
+// Package vault implements config.SecretResolver on top of a HashiCorp Vault
+// KV v2 secrets engine. It is its own Go module (see go.mod in this
+// directory) so that the Vault API client and its dependency tree are only
+// pulled in by callers that actually import this package; the config
+// package itself never depends on it.
+package vault
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Resolver resolves config secret tags against a KV v2 mount in Vault.
+type Resolver struct {
+	client *api.Client
+}
+
+// New wraps an already-configured Vault client.
+func New(client *api.Client) *Resolver {
+	return &Resolver{client: client}
+}
+
+// NewWithAddress builds a Vault client from an address and a static token,
+// the common case for services that just need to read secrets at startup.
+func NewWithAddress(addr, token string) (*Resolver, error) {
+	cfg := api.DefaultConfig()
+	cfg.Address = addr
+
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("vault: new client: %w", err)
+	}
+	client.SetToken(token)
+
+	return New(client), nil
+}
+
+// Resolve reads path and returns its KV v2 data as strings, keyed by field
+// name. Non-string values are rejected rather than silently stringified.
+func (r *Resolver) Resolve(path string) (map[string]string, error) {
+	secret, err := r.client.Logical().Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("vault: read %q: %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault: no secret at %q", path)
+	}
+
+	raw, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		raw = secret.Data
+	}
+
+	data := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("vault: %q: key %q is not a string", path, k)
+		}
+		data[k] = s
+	}
+
+	return data, nil
+}
@@ -0,0 +1,93 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, path string, body map[string]interface{}) *Resolver {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/"+path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := vaultapi.DefaultConfig()
+	cfg.Address = srv.URL
+
+	client, err := vaultapi.NewClient(cfg)
+	require.NoError(t, err)
+	client.SetToken("test-token")
+
+	return New(client)
+}
+
+func TestResolveKVv2UnwrapsDataData(t *testing.T) {
+	r := newTestServer(t, "secret/data/myapp/db", map[string]interface{}{
+		"data": map[string]interface{}{
+			"data": map[string]interface{}{
+				"password": "hunter2",
+				"port":     "5432",
+			},
+		},
+	})
+
+	data, err := r.Resolve("secret/data/myapp/db")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "hunter2", "port": "5432"}, data)
+}
+
+func TestResolveFallsBackToFlatDataWhenNoNestedData(t *testing.T) {
+	r := newTestServer(t, "secret/myapp/db", map[string]interface{}{
+		"data": map[string]interface{}{
+			"password": "hunter2",
+		},
+	})
+
+	data, err := r.Resolve("secret/myapp/db")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "hunter2"}, data)
+}
+
+func TestResolveRejectsNonStringValues(t *testing.T) {
+	r := newTestServer(t, "secret/data/myapp/db", map[string]interface{}{
+		"data": map[string]interface{}{
+			"data": map[string]interface{}{
+				"port": 5432,
+			},
+		},
+	})
+
+	_, err := r.Resolve("secret/data/myapp/db")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port")
+}
+
+func TestResolveNoSecretAtPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := vaultapi.DefaultConfig()
+	cfg.Address = srv.URL
+	client, err := vaultapi.NewClient(cfg)
+	require.NoError(t, err)
+	client.SetToken("test-token")
+
+	r := New(client)
+	_, err = r.Resolve("secret/data/missing")
+	require.Error(t, err)
+}
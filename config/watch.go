@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WithReloadSignal makes Watch also reload when the process receives sig,
+// for environments where filesystem watching isn't reliable (containers
+// with mounted configmaps).
+func WithReloadSignal(sig os.Signal) Option {
+	return func(o *configOptions) {
+		o.reloadSignal = sig
+	}
+}
+
+// Snapshot holds the current value of a config being kept up to date by
+// Watch, safe for concurrent reads while a reload swaps it in.
+type Snapshot[T any] struct {
+	mu  sync.RWMutex
+	val *T
+}
+
+// Load returns the current value.
+func (s *Snapshot[T]) Load() *T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.val
+}
+
+func (s *Snapshot[T]) store(v *T) {
+	s.mu.Lock()
+	s.val = v
+	s.mu.Unlock()
+}
+
+const watchDebounce = 200 * time.Millisecond
+
+// Watch loads dest the same way Load does, then keeps the returned Snapshot
+// current: writes to ".env" or any file passed via WithConfigFile(s), and a
+// signal given via WithReloadSignal, trigger a reload into a fresh value that
+// replaces the Snapshot's; dest itself keeps the initially loaded value. A
+// reload that fails validation is logged at warn level and discarded, keeping
+// the last good value in place.
+// onChange, if non-nil, is called with deep copies of the old and new
+// values after every successful reload. Call the returned stop func to
+// release the watcher.
+func Watch[T any](dest *T, onChange func(old, new *T), opts ...Option) (*Snapshot[T], func(), error) {
+	options := &configOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := Load(dest, opts...); err != nil {
+		return nil, nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, fmt.Errorf("config: watch: %w", err)
+	}
+
+	for _, path := range watchPaths(options) {
+		if err := watcher.Add(path); err != nil {
+			slog.Warn("config: watch: cannot watch file", "path", path, "error", err)
+		}
+	}
+
+	var sigCh chan os.Signal
+	if options.reloadSignal != nil {
+		sigCh = make(chan os.Signal, 1)
+		signal.Notify(sigCh, options.reloadSignal)
+	}
+
+	snap := &Snapshot[T]{val: dest}
+	done := make(chan struct{})
+
+	go watchLoop(watcher, sigCh, done, snap, onChange, opts)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			watcher.Close()
+			if sigCh != nil {
+				signal.Stop(sigCh)
+			}
+		})
+	}
+
+	return snap, stop, nil
+}
+
+func watchPaths(options *configOptions) []string {
+	paths := make([]string, 0, len(options.configFiles)+1)
+	if _, err := os.Stat("./.env"); err == nil {
+		paths = append(paths, "./.env")
+	}
+	paths = append(paths, options.configFiles...)
+	return paths
+}
+
+func watchLoop[T any](
+	watcher *fsnotify.Watcher,
+	sigCh chan os.Signal,
+	done chan struct{},
+	snap *Snapshot[T],
+	onChange func(old, new *T),
+	opts []Option,
+) {
+	reload := make(chan struct{}, 1)
+	debounce := func() {
+		time.AfterFunc(watchDebounce, func() {
+			select {
+			case reload <- struct{}{}:
+			default:
+			}
+		})
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+				debounce()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("config: watch: error", "error", err)
+		case <-sigCh:
+			debounce()
+		case <-reload:
+			reloadOnce(snap, onChange, opts)
+		}
+	}
+}
+
+func reloadOnce[T any](snap *Snapshot[T], onChange func(old, new *T), opts []Option) {
+	var next T
+	if err := Load(&next, opts...); err != nil {
+		slog.Warn("config: watch: reload failed, keeping previous value", "error", err)
+		return
+	}
+
+	old := snap.Load()
+	snap.store(&next)
+
+	if onChange != nil {
+		onChange(deepCopyConfig(old), deepCopyConfig(&next))
+	}
+}
+
+// deepCopyConfig copies v via a JSON round-trip. It falls back to a shallow
+// copy if v fails to marshal or unmarshal. Unexported fields, and any field
+// JSON skips, do not survive the round-trip and are left zero in the copy.
+func deepCopyConfig[T any](v *T) *T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		cp := *v
+		return &cp
+	}
+
+	var cp T
+	if err := json.Unmarshal(b, &cp); err != nil {
+		cp = *v
+	}
+
+	return &cp
+}
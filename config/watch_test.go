@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchConfig struct {
+	Host string `env:"WATCH_HOST" validate:"required"`
+}
+
+func TestWatchReloadsOnFileChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("watch_host = \"first\"\n"), 0644))
+
+	cfg := &watchConfig{}
+
+	type change struct{ old, new watchConfig }
+	changes := make(chan change, 1)
+
+	snap, stop, err := Watch(cfg, func(old, next *watchConfig) {
+		changes <- change{*old, *next}
+	}, SkipEnvFile(), SkipValidation(), WithConfigFile(path))
+	require.NoError(t, err)
+	defer stop()
+
+	assert.Equal(t, "first", snap.Load().Host)
+
+	require.NoError(t, os.WriteFile(path, []byte("watch_host = \"second\"\n"), 0644))
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, "first", c.old.Host)
+		assert.Equal(t, "second", c.new.Host)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	assert.Equal(t, "second", snap.Load().Host)
+}
+
+func TestWatchKeepsPreviousValueOnValidationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("watch_host = \"first\"\n"), 0644))
+
+	cfg := &watchConfig{}
+	snap, stop, err := Watch(cfg, nil, SkipEnvFile(), WithConfigFile(path))
+	require.NoError(t, err)
+	defer stop()
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0644))
+	time.Sleep(500 * time.Millisecond)
+
+	assert.Equal(t, "first", snap.Load().Host)
+}
+
+func TestWatchStopIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("watch_host = \"first\"\n"), 0644))
+
+	cfg := &watchConfig{}
+	_, stop, err := Watch(cfg, nil, SkipEnvFile(), WithConfigFile(path))
+	require.NoError(t, err)
+
+	assert.NotPanics(t, func() {
+		stop()
+		stop()
+	})
+}
@@ -10,9 +10,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/mdobak/go-xerrors"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type Config struct {
@@ -27,6 +29,10 @@ type PrettyHandlerOptions struct {
 type PrettyHandler struct {
 	slog.Handler
 	l *log.Logger
+	// attrs accumulates attributes added via WithAttrs, since the embedded
+	// Handler's own copy is only consulted if we delegate Handle to it (we
+	// don't — we render the fields ourselves).
+	attrs []slog.Attr
 }
 
 type stackFrame struct {
@@ -49,21 +55,48 @@ func (h *PrettyHandler) Handle(ctx context.Context, r slog.Record) error {
 		level = color.RedString(level)
 	}
 
-	fields := make(map[string]interface{}, r.NumAttrs())
-	r.Attrs(func(a slog.Attr) bool {
+	var errStacks []errorStack
+
+	fields := make(map[string]interface{}, r.NumAttrs()+len(h.attrs))
+	addAttr := func(a slog.Attr) {
 		switch a.Value.Kind() {
 		case slog.KindAny:
 			switch v := a.Value.Any().(type) {
 			case error:
-				a.Value = slog.StringValue(v.Error())
+				entry, frames := groupValueToMap(fmtErr(v))
+				fields[a.Key] = entry
+				if frames != nil {
+					errStacks = append(errStacks, errorStack{key: a.Key, err: v, frames: frames})
+				}
+				return
 			}
 		}
 
 		// Handle other attributes normally
 		fields[a.Key] = a.Value.Any()
+	}
+
+	// attrs added via WithAttrs (e.g. logger.With(...)) aren't part of the
+	// record itself, so they must be merged in separately.
+	for _, a := range h.attrs {
+		addAttr(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(a)
 		return true
 	})
 
+	if span := oteltrace.SpanContextFromContext(ctx); span.IsValid() {
+		fields["trace_id"] = span.TraceID().String()
+		fields["span_id"] = span.SpanID().String()
+	}
+
+	if r.Level >= slog.LevelError {
+		if span := oteltrace.SpanFromContext(ctx); span.IsRecording() {
+			span.AddEvent(r.Message)
+		}
+	}
+
 	b, err := json.MarshalIndent(fields, "", "  ")
 	if err != nil {
 		return err
@@ -79,11 +112,47 @@ func (h *PrettyHandler) Handle(ctx context.Context, r slog.Record) error {
 		fileStr = color.GreenString(frame.File) + ":" + color.GreenString(fmt.Sprint(frame.Line))
 	}
 
-	h.l.Println(timeStr, level, msg, fileStr, color.WhiteString(string(b)))
+	var out strings.Builder
+	fmt.Fprintln(&out, timeStr, level, msg, fileStr, color.WhiteString(string(b)))
+
+	for _, es := range errStacks {
+		fmt.Fprintln(&out, color.RedString("  ↳ %s: %s", es.key, es.err.Error()))
+		for _, f := range es.frames {
+			fmt.Fprintln(&out, color.HiBlackString("      at %s (%s:%d)", f.Func, f.Source, f.Line))
+		}
+	}
+
+	// A single Println call so the main line and stack block can't be
+	// interleaved with another goroutine's Handle call on the shared writer.
+	h.l.Println(strings.TrimSuffix(out.String(), "\n"))
 
 	return nil
 }
 
+// errorStack pairs an error-valued attribute with its extracted frames, for
+// the colorized block printed beneath the main pretty log line.
+type errorStack struct {
+	key    string
+	err    error
+	frames []stackFrame
+}
+
+// groupValueToMap flattens a fmtErr group value into a plain map suitable
+// for JSON embedding, also returning the trace frames if present.
+func groupValueToMap(v slog.Value) (map[string]interface{}, []stackFrame) {
+	m := make(map[string]interface{})
+	var frames []stackFrame
+
+	for _, attr := range v.Group() {
+		m[attr.Key] = attr.Value.Any()
+		if f, ok := attr.Value.Any().([]stackFrame); ok {
+			frames = f
+		}
+	}
+
+	return m, frames
+}
+
 func replaceAttr(_ []string, a slog.Attr) slog.Attr {
 	switch a.Value.Kind() {
 	case slog.KindAny:
@@ -142,6 +211,18 @@ func fmtErr(err error) slog.Value {
 	return slog.GroupValue(groupValues...)
 }
 
+func (h *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &PrettyHandler{
+		Handler: h.Handler.WithAttrs(attrs),
+		l:       h.l,
+		attrs:   append(append([]slog.Attr{}, h.attrs...), attrs...),
+	}
+}
+
+func (h *PrettyHandler) WithGroup(name string) slog.Handler {
+	return &PrettyHandler{Handler: h.Handler.WithGroup(name), l: h.l, attrs: h.attrs}
+}
+
 func NewPrettyHandler(
 	out io.Writer,
 	opts PrettyHandlerOptions,
@@ -167,6 +248,58 @@ func NewPrettyHandlerWithDefaults(level slog.Level) *PrettyHandler {
 	)
 }
 
-func NewJSONHandlerWithDefaults(level slog.Level) *slog.JSONHandler {
-	return slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level, AddSource: true, ReplaceAttr: replaceAttr})
+// jsonHandler wraps slog.JSONHandler to inject OTel trace/span IDs and to
+// render error attributes with their stack trace (see fmtErr) instead of
+// just their message.
+type jsonHandler struct {
+	slog.Handler
+}
+
+func (h *jsonHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	converted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		if v, ok := a.Value.Any().(error); ok {
+			a = slog.Attr{Key: a.Key, Value: fmtErr(v)}
+		}
+		converted[i] = a
+	}
+	return &jsonHandler{Handler: h.Handler.WithAttrs(converted)}
+}
+
+func (h *jsonHandler) WithGroup(name string) slog.Handler {
+	return &jsonHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+func (h *jsonHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+
+	r.Attrs(func(a slog.Attr) bool {
+		if v, ok := a.Value.Any().(error); ok {
+			nr.AddAttrs(slog.Attr{Key: a.Key, Value: fmtErr(v)})
+			return true
+		}
+		nr.AddAttrs(a)
+		return true
+	})
+
+	if span := oteltrace.SpanContextFromContext(ctx); span.IsValid() {
+		nr.AddAttrs(
+			slog.String("trace_id", span.TraceID().String()),
+			slog.String("span_id", span.SpanID().String()),
+		)
+	}
+
+	if r.Level >= slog.LevelError {
+		if span := oteltrace.SpanFromContext(ctx); span.IsRecording() {
+			span.AddEvent(r.Message)
+		}
+	}
+
+	return h.Handler.Handle(ctx, nr)
+}
+
+func NewJSONHandlerWithDefaults(level slog.Level) slog.Handler {
+	return &jsonHandler{
+		Handler: slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level, AddSource: true, ReplaceAttr: replaceAttr}),
+	}
 }
@@ -0,0 +1,175 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdobak/go-xerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func contextWithSpan(t *testing.T) context.Context {
+	t.Helper()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestPrettyHandlerInjectsTraceAndSpanIDs(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewPrettyHandler(&buf, PrettyHandlerOptions{SlogOpts: slog.HandlerOptions{Level: slog.LevelDebug}})
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	require.NoError(t, h.Handle(contextWithSpan(t), r))
+
+	out := buf.String()
+	assert.Contains(t, out, "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Contains(t, out, "00f067aa0ba902b7")
+}
+
+func TestPrettyHandlerRendersStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewPrettyHandler(&buf, PrettyHandlerOptions{SlogOpts: slog.HandlerOptions{Level: slog.LevelDebug}})
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, "failed", 0)
+	r.AddAttrs(slog.Any("err", xerrors.New("boom")))
+
+	require.NoError(t, h.Handle(context.Background(), r))
+
+	out := buf.String()
+	assert.Contains(t, out, "boom")
+	assert.Contains(t, out, "↳")
+	assert.Contains(t, out, "at ")
+}
+
+func TestPrettyHandlerErrorWithoutStackHasNoFrames(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewPrettyHandler(&buf, PrettyHandlerOptions{SlogOpts: slog.HandlerOptions{Level: slog.LevelDebug}})
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, "failed", 0)
+	r.AddAttrs(slog.Any("err", errors.New("plain failure")))
+
+	require.NoError(t, h.Handle(context.Background(), r))
+
+	out := buf.String()
+	assert.Contains(t, out, "plain failure")
+	assert.NotContains(t, out, "↳")
+}
+
+// countingWriter records how many Write calls it receives.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestPrettyHandlerSingleWritePerHandle(t *testing.T) {
+	var buf countingWriter
+	h := NewPrettyHandler(&buf, PrettyHandlerOptions{SlogOpts: slog.HandlerOptions{Level: slog.LevelDebug}})
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, "failed", 0)
+	r.AddAttrs(slog.Any("err", xerrors.New("boom")))
+
+	require.NoError(t, h.Handle(context.Background(), r))
+
+	// A single Println call means the whole record (main line plus stack
+	// block) is one write ending in exactly one trailing newline.
+	assert.Equal(t, 1, buf.writes)
+	trimmed := strings.TrimSuffix(buf.String(), "\n")
+	assert.False(t, strings.HasSuffix(trimmed, "\n"))
+}
+
+func TestJSONHandlerInjectsTraceAndSpanIDs(t *testing.T) {
+	var buf bytes.Buffer
+	h := &jsonHandler{Handler: slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})}
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	require.NoError(t, h.Handle(contextWithSpan(t), r))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", decoded["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", decoded["span_id"])
+}
+
+func TestJSONHandlerRendersErrorStack(t *testing.T) {
+	var buf bytes.Buffer
+	h := &jsonHandler{Handler: slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})}
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, "failed", 0)
+	r.AddAttrs(slog.Any("err", xerrors.New("boom")))
+
+	require.NoError(t, h.Handle(context.Background(), r))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	errField, ok := decoded["err"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "boom", errField["msg"])
+	assert.NotEmpty(t, errField["trace"])
+}
+
+func TestJSONHandlerWithAttrsPreservesEnrichment(t *testing.T) {
+	var buf bytes.Buffer
+	h := &jsonHandler{Handler: slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})}
+
+	logger := slog.New(h).With("component", "x")
+	logger.ErrorContext(contextWithSpan(t), "failed", slog.Any("err", xerrors.New("boom")))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "x", decoded["component"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", decoded["trace_id"])
+
+	errField, ok := decoded["err"].(map[string]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, errField["trace"])
+}
+
+func TestPrettyHandlerWithAttrsPreservesEnrichment(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewPrettyHandler(&buf, PrettyHandlerOptions{SlogOpts: slog.HandlerOptions{Level: slog.LevelDebug}})
+
+	logger := slog.New(h).With("component", "x")
+	logger.ErrorContext(contextWithSpan(t), "failed", slog.Any("err", xerrors.New("boom")))
+
+	out := buf.String()
+	assert.Contains(t, out, "component")
+	assert.Contains(t, out, "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Contains(t, out, "00f067aa0ba902b7")
+	assert.Contains(t, out, "↳")
+	assert.Contains(t, out, "at ")
+}
+
+func TestPrettyHandlerWithGroupPreservesEnrichment(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewPrettyHandler(&buf, PrettyHandlerOptions{SlogOpts: slog.HandlerOptions{Level: slog.LevelDebug}})
+
+	logger := slog.New(h).WithGroup("request")
+	logger.InfoContext(contextWithSpan(t), "hello")
+
+	assert.Contains(t, buf.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+}